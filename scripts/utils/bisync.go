@@ -0,0 +1,218 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// bisyncConflict is one file rclone's bisync flagged as changed on both
+// sides, identified by the common base path it suffixes with
+// ".conflict1"/".conflict2".
+type bisyncConflict struct {
+	base string
+}
+
+// bisyncResolution is the user's choice for a single conflict.
+type bisyncResolution string
+
+const (
+	resolutionLocalWins  bisyncResolution = "local"
+	resolutionRemoteWins bisyncResolution = "remote"
+	resolutionKeepBoth   bisyncResolution = "keep-both"
+)
+
+// bisyncRunState is persisted per-remote so pxsync knows whether the next
+// run needs --resync (first run, or the previous run didn't finish
+// cleanly).
+type bisyncRunState struct {
+	LastRunSucceeded bool `json:"lastRunSucceeded"`
+}
+
+// conflictFilePattern matches the path field of an rclone bisync conflict
+// notice, e.g. `2026/07/27 09:11:00 NOTICE: foo/bar.conflict1: Path is a
+// conflict`. It anchors on the literal "NOTICE:" prefix rclone emits and
+// a non-whitespace path token, rather than matching greedily to the end
+// of the line -- which would otherwise capture the timestamp/level
+// prefix into the base path.
+var conflictFilePattern = regexp.MustCompile(`NOTICE:\s*(\S+)\.conflict[12]\s*:`)
+
+func bisyncWorkDir(cfg config) (string, error) {
+	stateDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve home directory: %w", err)
+	}
+	return filepath.Join(stateDir, ".local", "state", "pxsync", "bisync", cfg.remoteName), nil
+}
+
+func bisyncStateFile(cfg config) (string, error) {
+	dir, err := bisyncWorkDir(cfg)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "state.json"), nil
+}
+
+func loadBisyncRunState(cfg config) bisyncRunState {
+	path, err := bisyncStateFile(cfg)
+	if err != nil {
+		return bisyncRunState{}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return bisyncRunState{}
+	}
+	var state bisyncRunState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return bisyncRunState{}
+	}
+	return state
+}
+
+func saveBisyncRunState(cfg config, state bisyncRunState) error {
+	path, err := bisyncStateFile(cfg)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create bisync state directory: %w", err)
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode bisync state: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// bisyncStart runs `rclone bisync`, using --resync whenever the previous
+// run never finished cleanly (or this is the first run for this remote),
+// and reports back either the conflicts it found or that it's done.
+func bisyncStart(cfg config) tea.Cmd {
+	return func() tea.Msg {
+		workDir, err := bisyncWorkDir(cfg)
+		if err != nil {
+			return bisyncDoneMsg{err: err}
+		}
+		if err := os.MkdirAll(workDir, 0755); err != nil {
+			return bisyncDoneMsg{err: fmt.Errorf("failed to create bisync workdir: %w", err)}
+		}
+
+		runState := loadBisyncRunState(cfg)
+		needsResync := !runState.LastRunSucceeded
+
+		conflicts, err := runBisync(cfg, workDir, needsResync, "")
+		if err != nil {
+			_ = saveBisyncRunState(cfg, bisyncRunState{LastRunSucceeded: false})
+			return bisyncDoneMsg{err: err}
+		}
+		if len(conflicts) > 0 {
+			return bisyncConflictsMsg{conflicts: conflicts}
+		}
+
+		_ = saveBisyncRunState(cfg, bisyncRunState{LastRunSucceeded: true})
+		return bisyncDoneMsg{}
+	}
+}
+
+// resolveBisyncConflicts re-runs bisync with a generated filters file that
+// excludes whichever side the user didn't pick for each conflict, then
+// re-invokes bisync (without --resync, since the first pass already did
+// the heavy lifting) so it can fold the resolution back in.
+func resolveBisyncConflicts(cfg config, conflicts []bisyncConflict, resolutions map[string]bisyncResolution) tea.Cmd {
+	return func() tea.Msg {
+		workDir, err := bisyncWorkDir(cfg)
+		if err != nil {
+			return bisyncDoneMsg{err: err}
+		}
+
+		filtersFile := filepath.Join(workDir, "conflict-filters.txt")
+		if err := writeConflictFilters(filtersFile, conflicts, resolutions); err != nil {
+			return bisyncDoneMsg{err: err}
+		}
+
+		remaining, err := runBisync(cfg, workDir, false, filtersFile)
+		if err != nil {
+			_ = saveBisyncRunState(cfg, bisyncRunState{LastRunSucceeded: false})
+			return bisyncDoneMsg{err: err}
+		}
+		if len(remaining) > 0 {
+			return bisyncConflictsMsg{conflicts: remaining}
+		}
+
+		_ = saveBisyncRunState(cfg, bisyncRunState{LastRunSucceeded: true})
+		return bisyncDoneMsg{}
+	}
+}
+
+// writeConflictFilters turns each resolution into an rclone filter rule:
+// local-wins excludes the remote's copy (and vice versa), keep-both
+// excludes neither so both .conflict1/.conflict2 files survive.
+func writeConflictFilters(path string, conflicts []bisyncConflict, resolutions map[string]bisyncResolution) error {
+	var b strings.Builder
+	for _, c := range conflicts {
+		switch resolutions[c.base] {
+		case resolutionLocalWins:
+			fmt.Fprintf(&b, "- %s.conflict2\n", c.base)
+		case resolutionRemoteWins:
+			fmt.Fprintf(&b, "- %s.conflict1\n", c.base)
+		case resolutionKeepBoth:
+			// No exclusion: both conflict files are kept as-is.
+		}
+	}
+	b.WriteString("+ **\n")
+
+	if err := os.WriteFile(path, []byte(b.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write conflict filters file: %w", err)
+	}
+	return nil
+}
+
+// runBisync invokes `rclone bisync` and scans its output for conflict
+// filenames.
+func runBisync(cfg config, workDir string, resync bool, filtersFile string) ([]bisyncConflict, error) {
+	args := []string{
+		"bisync",
+		cfg.localDir,
+		cfg.remoteName + ":" + cfg.remoteBasePath,
+		"--workdir", workDir,
+		"--exclude-from", cfg.exclusionFile,
+	}
+	if resync {
+		args = append(args, "--resync")
+	}
+	if filtersFile != "" {
+		args = append(args, "--filters-file", filtersFile)
+	}
+
+	out, err := exec.Command("rclone", args...).CombinedOutput()
+	conflicts := parseBisyncConflicts(string(out))
+	if err != nil && len(conflicts) == 0 {
+		return nil, fmt.Errorf("rclone bisync failed: %w: %s", err, truncate(string(out), 500))
+	}
+	return conflicts, nil
+}
+
+func parseBisyncConflicts(output string) []bisyncConflict {
+	seen := map[string]bool{}
+	var conflicts []bisyncConflict
+
+	for _, line := range strings.Split(output, "\n") {
+		m := conflictFilePattern.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		base := m[1]
+		if seen[base] {
+			continue
+		}
+		seen[base] = true
+		conflicts = append(conflicts, bisyncConflict{base: base})
+	}
+	return conflicts
+}
@@ -0,0 +1,51 @@
+package main
+
+import "testing"
+
+func TestParseBisyncConflictsAnchorsOnNoticePrefix(t *testing.T) {
+	// Realistic rclone bisync log output: timestamp/level prefix, then the
+	// NOTICE line bisync emits per conflict. A regex that isn't anchored to
+	// the NOTICE: field would swallow the timestamp into the captured path.
+	output := `2026/07/27 09:11:00 INFO  : Bisync is starting
+2026/07/27 09:11:02 NOTICE: foo/bar.conflict1: Path is a conflict
+2026/07/27 09:11:02 NOTICE: foo/bar.conflict2: Path is a conflict
+2026/07/27 09:11:03 NOTICE: baz/qux.conflict1: Path is a conflict
+2026/07/27 09:11:05 INFO  : Bisync successful
+`
+
+	conflicts := parseBisyncConflicts(output)
+
+	if len(conflicts) != 2 {
+		t.Fatalf("parseBisyncConflicts() returned %d conflicts, want 2: %+v", len(conflicts), conflicts)
+	}
+
+	bases := map[string]bool{}
+	for _, c := range conflicts {
+		bases[c.base] = true
+	}
+	for _, want := range []string{"foo/bar", "baz/qux"} {
+		if !bases[want] {
+			t.Errorf("parseBisyncConflicts() missing base %q, got %+v", want, conflicts)
+		}
+	}
+}
+
+func TestParseBisyncConflictsDedupesBothSidesOfAConflict(t *testing.T) {
+	output := `2026/07/27 09:11:02 NOTICE: foo/bar.conflict1: Path is a conflict
+2026/07/27 09:11:02 NOTICE: foo/bar.conflict2: Path is a conflict
+`
+
+	conflicts := parseBisyncConflicts(output)
+
+	if len(conflicts) != 1 || conflicts[0].base != "foo/bar" {
+		t.Fatalf("parseBisyncConflicts() = %+v, want a single entry for base \"foo/bar\"", conflicts)
+	}
+}
+
+func TestParseBisyncConflictsIgnoresUnrelatedLines(t *testing.T) {
+	output := "2026/07/27 09:11:00 INFO  : Bisync is starting\nsome other noise\n"
+
+	if conflicts := parseBisyncConflicts(output); len(conflicts) != 0 {
+		t.Fatalf("parseBisyncConflicts() = %+v, want none", conflicts)
+	}
+}
@@ -0,0 +1,15 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func newBisyncCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "bisync",
+		Short: "Two-way sync with the remote, prompting to resolve any conflicts",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBisyncTUI(resolveConfig(flags))
+		},
+	}
+}
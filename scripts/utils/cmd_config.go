@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func newConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect or edit the persisted pxsync configuration",
+	}
+
+	cmd.AddCommand(newConfigShowCmd(), newConfigSetCmd(), newConfigResetCmd())
+	return cmd
+}
+
+func newConfigShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show",
+		Short: "Print the fully resolved configuration (defaults + persisted + flags)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := resolveConfig(flags)
+			fmt.Printf("remote         = %s\n", cfg.remoteName)
+			fmt.Printf("remoteHost     = %s\n", cfg.remoteHost)
+			fmt.Printf("remoteUser     = %s\n", cfg.remoteUser)
+			fmt.Printf("localDir       = %s\n", cfg.localDir)
+			fmt.Printf("remoteBasePath = %s\n", cfg.remoteBasePath)
+			fmt.Printf("exclusionFile  = %s\n", cfg.exclusionFile)
+			fmt.Printf("sshKey         = %s\n", cfg.sshKey)
+			fmt.Printf("healthInterval = %s\n", cfg.healthInterval)
+			return nil
+		},
+	}
+}
+
+func newConfigSetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Persist a config value (remote, host, user, key, local, base-path, exclude-file, health-interval)",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pc, err := loadPersistedConfig()
+			if err != nil {
+				return err
+			}
+
+			key, value := args[0], args[1]
+			switch key {
+			case "remote":
+				pc.RemoteName = value
+			case "host":
+				pc.RemoteHost = value
+			case "user":
+				pc.RemoteUser = value
+			case "local":
+				pc.LocalDir = value
+			case "base-path":
+				pc.RemoteBasePath = value
+			case "exclude-file":
+				pc.ExclusionFile = value
+			case "key":
+				pc.SSHKey = value
+			case "health-interval":
+				if _, err := time.ParseDuration(value); err != nil {
+					return fmt.Errorf("invalid health-interval %q: %w", value, err)
+				}
+				pc.HealthInterval = value
+			default:
+				return fmt.Errorf("unknown config key %q (want one of: remote, host, user, local, base-path, exclude-file, key, health-interval)", key)
+			}
+
+			return savePersistedConfig(pc)
+		},
+	}
+}
+
+func newConfigResetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "reset",
+		Short: "Clear the persisted configuration, falling back to built-in defaults",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return savePersistedConfig(persistedConfig{})
+		},
+	}
+}
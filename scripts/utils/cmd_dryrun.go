@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/pixelatedempathy/pixelated/scripts/utils/i18n"
+)
+
+// diffEntry is one line of a dry-run plan: a file rclone would add/update
+// or delete if the flag were dropped.
+type diffEntry struct {
+	kind string // "change" or "delete"
+	path string
+}
+
+var dryRunLinePattern = regexp.MustCompile(`^.*NOTICE:\s+(.+?):\s+Skipped (copy|delete) as --dry-run is set`)
+
+func newDryRunCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "dry-run",
+		Short: "Show what sync would change without transferring anything",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := resolveConfig(flags)
+			if _, _, err := buildExclusionFile(cfg); err != nil {
+				return err
+			}
+			entries, err := planDryRun(cfg)
+			if err != nil {
+				return err
+			}
+			_, err = tea.NewProgram(newDryRunModel(entries)).Run()
+			return err
+		},
+	}
+}
+
+// planDryRun shells out to `rclone sync --dry-run` and parses its verbose
+// log into the set of changes a real sync would make.
+func planDryRun(cfg config) ([]diffEntry, error) {
+	args := []string{
+		"sync",
+		cfg.localDir,
+		cfg.remoteName + ":" + cfg.remoteBasePath,
+		"--dry-run",
+		"--verbose",
+		"--exclude-from", cfg.exclusionFile,
+	}
+
+	cmd := exec.Command("rclone", args...)
+	out, err := cmd.CombinedOutput()
+	// rclone exits non-zero for dry-run summaries in some versions; only
+	// bail if we got nothing usable back.
+	if err != nil && len(out) == 0 {
+		return nil, fmt.Errorf("rclone dry-run failed: %w", err)
+	}
+
+	var entries []diffEntry
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+	for scanner.Scan() {
+		line := scanner.Text()
+		m := dryRunLinePattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		kind := "change"
+		if m[2] == "delete" {
+			kind = "delete"
+		}
+		entries = append(entries, diffEntry{kind: kind, path: m[1]})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse rclone dry-run output: %w", err)
+	}
+
+	return entries, nil
+}
+
+// dryRunModel is a minimal scrollable list, styled to match the sync TUI,
+// rather than a full sync/refresh flow -- the plan is computed once
+// up front and the model just lets the user page through it.
+type dryRunModel struct {
+	entries []diffEntry
+	cursor  int
+	height  int
+}
+
+func newDryRunModel(entries []diffEntry) dryRunModel {
+	return dryRunModel{entries: entries, height: 20}
+}
+
+func (m dryRunModel) Init() tea.Cmd { return nil }
+
+func (m dryRunModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height - 6
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.entries)-1 {
+				m.cursor++
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m dryRunModel) View() string {
+	var s strings.Builder
+
+	adds, dels := 0, 0
+	for _, e := range m.entries {
+		if e.kind == "delete" {
+			dels++
+		} else {
+			adds++
+		}
+	}
+
+	s.WriteString(headerStyle.Render(i18n.Tf("Dry run: %d to add/update, %d to delete", adds, dels)))
+	s.WriteString("\n\n")
+
+	if len(m.entries) == 0 {
+		s.WriteString(successBoxStyle.Render(i18n.T("Nothing to sync -- local and remote already match.")))
+	} else {
+		start := 0
+		if m.cursor >= m.height {
+			start = m.cursor - m.height + 1
+		}
+		end := start + m.height
+		if end > len(m.entries) {
+			end = len(m.entries)
+		}
+
+		var lines []string
+		for i := start; i < end; i++ {
+			e := m.entries[i]
+			marker := "+"
+			style := greenStyle
+			if e.kind == "delete" {
+				marker = "-"
+				style = redStyle
+			}
+			line := style.Render(fmt.Sprintf("%s %s", marker, e.path))
+			if i == m.cursor {
+				line = lipgloss.NewStyle().Bold(true).Render("> ") + line
+			} else {
+				line = "  " + line
+			}
+			lines = append(lines, line)
+		}
+		s.WriteString(infoBoxStyle.Render(strings.Join(lines, "\n")))
+	}
+
+	s.WriteString("\n")
+	s.WriteString(greenStyle.Render(i18n.T("↑/↓ to scroll, 'q' to quit")))
+	return s.String()
+}
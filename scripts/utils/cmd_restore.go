@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pixelatedempathy/pixelated/scripts/utils/i18n"
+)
+
+func newRestoreCmd() *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Pull the remote back down over the local directory",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := resolveConfig(flags)
+			if !yes && !confirmRestore(cfg) {
+				fmt.Println(i18n.T("Restore cancelled."))
+				return nil
+			}
+			return runRestore(cfg)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "skip the confirmation prompt")
+	return cmd
+}
+
+func confirmRestore(cfg config) bool {
+	fmt.Print(i18n.Tf("This will overwrite %s with the contents of %s:%s. Continue? [y/N] ",
+		cfg.localDir, cfg.remoteName, cfg.remoteBasePath))
+
+	reader := bufio.NewReader(os.Stdin)
+	answer, _ := reader.ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// runRestore mirrors startSync but with source and destination swapped,
+// reusing the same rc daemon plumbing so restore gets the same progress
+// reporting the forward sync does.
+func runRestore(cfg config) error {
+	if err := os.MkdirAll(cfg.logDir, 0755); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(cfg.logDir) }()
+
+	rc, err := startRcDaemon(cfg, filepath.Join(cfg.logDir, "rcd.log"))
+	if err != nil {
+		return fmt.Errorf("failed to start rclone rc daemon: %w", err)
+	}
+	defer rc.shutdown()
+
+	out, err := rc.call("sync/copy", map[string]any{
+		"srcFs":       cfg.remoteName + ":" + cfg.remoteBasePath,
+		"dstFs":       cfg.localDir,
+		"_async":      true,
+		"_config":     map[string]any{"Transfers": 8, "Checkers": 4, "FastList": true},
+		"_filter":     map[string]any{"ExcludeRule": []string{"**/.git/**", "**/node_modules/**"}},
+		"createEmpty": true,
+	})
+	if err != nil {
+		return fmt.Errorf("restore failed: %w", err)
+	}
+
+	id, ok := out["jobid"].(float64)
+	if !ok {
+		return fmt.Errorf("rc sync/copy did not return a jobid: %v", out)
+	}
+	rc.jobID = int64(id)
+
+	fmt.Println(i18n.T("Restoring from remote..."))
+	for {
+		status, err := rc.jobStatus()
+		if err != nil {
+			return fmt.Errorf("failed to poll restore job: %w", err)
+		}
+		if status.Finished {
+			if status.Error != "" {
+				return fmt.Errorf("restore job failed: %s", status.Error)
+			}
+			break
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+
+	fmt.Println(i18n.T("✓ Restore completed successfully!"))
+	return nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pixelatedempathy/pixelated/scripts/utils/i18n"
+)
+
+// rcloneAbout mirrors the subset of `rclone about --json` we display.
+type rcloneAbout struct {
+	Total int64 `json:"total"`
+	Used  int64 `json:"used"`
+	Free  int64 `json:"free"`
+}
+
+func newStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show remote size and free space totals",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := resolveConfig(flags)
+			return printStatus(cfg)
+		},
+	}
+}
+
+func printStatus(cfg config) error {
+	remote := cfg.remoteName + ":" + cfg.remoteBasePath
+
+	sizeOut, err := exec.Command("rclone", "size", remote, "--json").Output()
+	if err != nil {
+		return fmt.Errorf("rclone size failed: %w", err)
+	}
+	var size struct {
+		Count int64 `json:"count"`
+		Bytes int64 `json:"bytes"`
+	}
+	if err := json.Unmarshal(sizeOut, &size); err != nil {
+		return fmt.Errorf("failed to parse rclone size output: %w", err)
+	}
+
+	aboutOut, err := exec.Command("rclone", "about", cfg.remoteName+":", "--json").Output()
+	if err != nil {
+		return fmt.Errorf("rclone about failed: %w", err)
+	}
+	var about rcloneAbout
+	if err := json.Unmarshal(aboutOut, &about); err != nil {
+		return fmt.Errorf("failed to parse rclone about output: %w", err)
+	}
+
+	fmt.Printf("%s\n", remote)
+	fmt.Printf("  %s %d\n", i18n.T("files:"), size.Count)
+	fmt.Printf("  %s %s\n", i18n.T("used here:"), formatBytes(size.Bytes))
+	fmt.Printf("  %s %s\n", i18n.T("total:"), formatBytes(about.Total))
+	fmt.Printf("  %s %s\n", i18n.T("free:"), formatBytes(about.Free))
+	return nil
+}
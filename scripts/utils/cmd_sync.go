@@ -0,0 +1,20 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func newSyncCmd() *cobra.Command {
+	var rehash bool
+
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Push local changes to the remote with a live progress TUI",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSyncTUI(resolveConfig(flags), rehash)
+		},
+	}
+
+	cmd.Flags().BoolVar(&rehash, "rehash", false, "force full-file hashing instead of trusting size+mtime (detects silent corruption)")
+	return cmd
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/cobra"
+
+	"github.com/pixelatedempathy/pixelated/scripts/utils/healthcheck"
+	"github.com/pixelatedempathy/pixelated/scripts/utils/i18n"
+)
+
+func newWatchCmd() *cobra.Command {
+	var debounce time.Duration
+	var metricsAddr string
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Watch the local directory and sync incrementally on change",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWatch(resolveConfig(flags), debounce, metricsAddr)
+		},
+	}
+
+	cmd.Flags().DurationVar(&debounce, "debounce", 5*time.Second, "quiet period after the last change before syncing")
+	cmd.Flags().StringVar(&metricsAddr, "metrics-addr", "", "if set, serve Prometheus health metrics on this address (e.g. :9090)")
+	return cmd
+}
+
+// runWatch recursively watches cfg.localDir and re-runs the sync TUI
+// whenever filesystem events settle for the debounce period.
+func runWatch(cfg config, debounce time.Duration, metricsAddr string) error {
+	if metricsAddr != "" {
+		go serveWatchMetrics(cfg, metricsAddr)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create filesystem watcher: %w", err)
+	}
+	defer func() { _ = watcher.Close() }()
+
+	if err := addWatchDirs(watcher, cfg.localDir); err != nil {
+		return err
+	}
+
+	fmt.Println(i18n.Tf("Watching %s (debounce %s). Press Ctrl+C to stop.", cfg.localDir, debounce))
+
+	var timer *time.Timer
+	trigger := make(chan struct{}, 1)
+
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if shouldIgnoreEvent(event) {
+				continue
+			}
+			if event.Op&(fsnotify.Create) != 0 {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					_ = addWatchDirs(watcher, event.Name)
+				}
+			}
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.AfterFunc(debounce, func() {
+				select {
+				case trigger <- struct{}{}:
+				default:
+				}
+			})
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Fprintln(os.Stderr, i18n.Tf("watch error: %v", err))
+
+		case <-trigger:
+			if err := runSyncTUI(cfg, false); err != nil {
+				fmt.Fprintln(os.Stderr, i18n.Tf("sync failed: %v", err))
+			}
+		}
+	}
+}
+
+func shouldIgnoreEvent(event fsnotify.Event) bool {
+	for _, skip := range []string{".git", "node_modules"} {
+		if filepath.Base(filepath.Dir(event.Name)) == skip {
+			return true
+		}
+	}
+	return false
+}
+
+// serveWatchMetrics runs the health probes on their own ticker, independent
+// of the TUI's own polling (each sync run spins up and tears down its own
+// Bubble Tea program), and exposes the results for scraping.
+func serveWatchMetrics(cfg config, addr string) {
+	runner := newHealthRunner(cfg)
+
+	var mu sync.Mutex
+	var latest []healthcheck.Result
+
+	go func() {
+		for {
+			results := runner.RunOnce(context.Background())
+			mu.Lock()
+			latest = results
+			mu.Unlock()
+			time.Sleep(cfg.healthInterval)
+		}
+	}()
+
+	getResults := func() []healthcheck.Result {
+		mu.Lock()
+		defer mu.Unlock()
+		return latest
+	}
+
+	if err := healthcheck.ServeMetrics(addr, getResults); err != nil {
+		fmt.Fprintln(os.Stderr, i18n.Tf("metrics server failed: %v", err))
+	}
+}
+
+func addWatchDirs(watcher *fsnotify.Watcher, root string) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		if info.Name() == ".git" || info.Name() == "node_modules" {
+			return filepath.SkipDir
+		}
+		return watcher.Add(path)
+	})
+}
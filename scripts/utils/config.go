@@ -0,0 +1,209 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// config is shared by every subcommand: sync, watch, dry-run, restore and
+// status all read from the same resolved values.
+type config struct {
+	remoteName     string
+	remoteHost     string
+	remoteUser     string
+	localDir       string
+	remoteBasePath string
+	exclusionFile  string
+	logDir         string
+	sshKey         string
+	healthInterval time.Duration
+}
+
+func defaultConfig() config {
+	homeDir, _ := os.UserHomeDir()
+	sshKey := os.Getenv("SSH_KEY")
+	if sshKey == "" {
+		sshKey = os.Getenv("PLANET_KEY")
+	}
+	if sshKey == "" {
+		// Try common locations
+		commonKeys := []string{
+			filepath.Join(homeDir, ".ssh", "planet"),
+			filepath.Join(homeDir, ".ssh", "id_rsa"),
+			filepath.Join(homeDir, ".ssh", "id_ed25519"),
+		}
+		for _, key := range commonKeys {
+			if _, err := os.Stat(key); err == nil {
+				sshKey = key
+				break
+			}
+		}
+	}
+
+	scriptDir := filepath.Dir(os.Args[0])
+	if scriptDir == "." {
+		execPath, err := os.Executable()
+		if err == nil {
+			scriptDir = filepath.Dir(execPath)
+		}
+	}
+
+	return config{
+		remoteName:     "planet",
+		remoteHost:     "146.71.78.184",
+		remoteUser:     "vivi",
+		localDir:       filepath.Join(homeDir, "pixelated"),
+		remoteBasePath: "~/pixelated",
+		exclusionFile:  filepath.Join(scriptDir, "rclone-exclusions-list.txt"),
+		logDir:         filepath.Join(os.TempDir(), fmt.Sprintf("rclone-ssh-sync-%d", os.Getpid())),
+		sshKey:         sshKey,
+		healthInterval: 30 * time.Second,
+	}
+}
+
+// globalFlags holds the values bound to the persistent flags on the root
+// command. applyTo overlays any flag the user actually set onto cfg, so
+// unset flags keep falling back to defaultConfig's discovery logic.
+type globalFlags struct {
+	remote         string
+	host           string
+	user           string
+	key            string
+	local          string
+	excludeFile    string
+	lang           string
+	healthInterval time.Duration
+}
+
+func (f globalFlags) applyTo(cfg config) config {
+	if f.remote != "" {
+		cfg.remoteName = f.remote
+	}
+	if f.host != "" {
+		cfg.remoteHost = f.host
+	}
+	if f.user != "" {
+		cfg.remoteUser = f.user
+	}
+	if f.key != "" {
+		cfg.sshKey = f.key
+	}
+	if f.local != "" {
+		cfg.localDir = f.local
+	}
+	if f.excludeFile != "" {
+		cfg.exclusionFile = f.excludeFile
+	}
+	if f.healthInterval != 0 {
+		cfg.healthInterval = f.healthInterval
+	}
+	return cfg
+}
+
+// persistedConfig is the on-disk shape written by `pxsync config set` and
+// read back by resolveConfig. Only fields the user has explicitly set are
+// present, so defaultConfig's discovery logic still applies to the rest.
+type persistedConfig struct {
+	RemoteName     string `json:"remoteName,omitempty"`
+	RemoteHost     string `json:"remoteHost,omitempty"`
+	RemoteUser     string `json:"remoteUser,omitempty"`
+	LocalDir       string `json:"localDir,omitempty"`
+	RemoteBasePath string `json:"remoteBasePath,omitempty"`
+	ExclusionFile  string `json:"exclusionFile,omitempty"`
+	SSHKey         string `json:"sshKey,omitempty"`
+	HealthInterval string `json:"healthInterval,omitempty"`
+}
+
+func configFilePath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve config directory: %w", err)
+	}
+	return filepath.Join(configDir, "pxsync", "config.json"), nil
+}
+
+func loadPersistedConfig() (persistedConfig, error) {
+	var pc persistedConfig
+
+	path, err := configFilePath()
+	if err != nil {
+		return pc, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return pc, nil
+	}
+	if err != nil {
+		return pc, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, &pc); err != nil {
+		return pc, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return pc, nil
+}
+
+func savePersistedConfig(pc persistedConfig) error {
+	path, err := configFilePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(pc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+func (pc persistedConfig) applyTo(cfg config) config {
+	if pc.RemoteName != "" {
+		cfg.remoteName = pc.RemoteName
+	}
+	if pc.RemoteHost != "" {
+		cfg.remoteHost = pc.RemoteHost
+	}
+	if pc.RemoteUser != "" {
+		cfg.remoteUser = pc.RemoteUser
+	}
+	if pc.LocalDir != "" {
+		cfg.localDir = pc.LocalDir
+	}
+	if pc.RemoteBasePath != "" {
+		cfg.remoteBasePath = pc.RemoteBasePath
+	}
+	if pc.ExclusionFile != "" {
+		cfg.exclusionFile = pc.ExclusionFile
+	}
+	if pc.SSHKey != "" {
+		cfg.sshKey = pc.SSHKey
+	}
+	if pc.HealthInterval != "" {
+		if d, err := time.ParseDuration(pc.HealthInterval); err == nil {
+			cfg.healthInterval = d
+		}
+	}
+	return cfg
+}
+
+// resolveConfig layers defaults, the persisted config file and the
+// process's command-line flags, in that order of increasing precedence.
+func resolveConfig(f globalFlags) config {
+	cfg := defaultConfig()
+
+	if pc, err := loadPersistedConfig(); err == nil {
+		cfg = pc.applyTo(cfg)
+	}
+
+	return f.applyTo(cfg)
+}
@@ -0,0 +1,65 @@
+package main
+
+import "testing"
+
+func TestGlobalFlagsApplyToOnlyOverridesSetFields(t *testing.T) {
+	base := config{
+		remoteName: "planet",
+		remoteHost: "146.71.78.184",
+		remoteUser: "vivi",
+		localDir:   "/home/vivi/pixelated",
+	}
+
+	got := globalFlags{remote: "moon", local: "/tmp/pixelated"}.applyTo(base)
+
+	want := base
+	want.remoteName = "moon"
+	want.localDir = "/tmp/pixelated"
+	if got != want {
+		t.Fatalf("applyTo() = %+v, want %+v", got, want)
+	}
+}
+
+func TestGlobalFlagsApplyToLeavesConfigUnchangedWhenNothingSet(t *testing.T) {
+	base := config{remoteName: "planet", remoteHost: "146.71.78.184"}
+
+	got := globalFlags{}.applyTo(base)
+
+	if got != base {
+		t.Fatalf("applyTo() = %+v, want unchanged %+v", got, base)
+	}
+}
+
+func TestPersistedConfigApplyToOnlyOverridesSetFields(t *testing.T) {
+	base := config{remoteName: "planet", remoteHost: "146.71.78.184", sshKey: "/home/vivi/.ssh/id_ed25519"}
+
+	got := persistedConfig{RemoteHost: "10.0.0.1"}.applyTo(base)
+
+	want := base
+	want.remoteHost = "10.0.0.1"
+	if got != want {
+		t.Fatalf("applyTo() = %+v, want %+v", got, want)
+	}
+}
+
+// TestConfigPrecedenceLayering exercises the same layering resolveConfig
+// does -- defaults, then the persisted config, then flags -- without
+// touching the filesystem, since defaultConfig/loadPersistedConfig depend
+// on the user's home/config directories.
+func TestConfigPrecedenceLayering(t *testing.T) {
+	defaults := config{remoteName: "planet", remoteHost: "146.71.78.184", localDir: "/home/vivi/pixelated"}
+	persisted := persistedConfig{RemoteHost: "10.0.0.1", LocalDir: "/srv/pixelated"}
+	flags := globalFlags{local: "/tmp/pixelated"}
+
+	cfg := flags.applyTo(persisted.applyTo(defaults))
+
+	if cfg.remoteName != "planet" {
+		t.Errorf("remoteName = %q, want default %q to survive untouched", cfg.remoteName, "planet")
+	}
+	if cfg.remoteHost != "10.0.0.1" {
+		t.Errorf("remoteHost = %q, want persisted value %q", cfg.remoteHost, "10.0.0.1")
+	}
+	if cfg.localDir != "/tmp/pixelated" {
+		t.Errorf("localDir = %q, want flag value %q to win over persisted", cfg.localDir, "/tmp/pixelated")
+	}
+}
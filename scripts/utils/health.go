@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/pixelatedempathy/pixelated/scripts/utils/healthcheck"
+	"github.com/pixelatedempathy/pixelated/scripts/utils/i18n"
+)
+
+// degradedThreshold is the number of consecutive all-probes-failed rounds
+// before the model gives up on the in-flight operation and surfaces
+// remediation hints instead.
+const degradedThreshold = 3
+
+func newHealthRunner(cfg config) *healthcheck.Runner {
+	return healthcheck.NewRunner([]healthcheck.Prober{
+		healthcheck.TCPProbe{Host: cfg.remoteHost, Port: 22},
+		healthcheck.SSHAuthProbe{Host: cfg.remoteHost, User: cfg.remoteUser, Key: cfg.sshKey},
+		healthcheck.RcloneListProbe{Remote: cfg.remoteName},
+		healthcheck.DiskFreeProbe{Remote: cfg.remoteName},
+		healthcheck.ClockSkewProbe{Host: cfg.remoteHost, User: cfg.remoteUser, Key: cfg.sshKey},
+	})
+}
+
+// healthTick runs every probe once and, after an initial delay so it
+// doesn't race the "init" state's own checks, schedules itself again.
+func healthTick(runner *healthcheck.Runner, delay time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		time.Sleep(delay)
+		return healthMsg{results: runner.RunOnce(context.Background())}
+	}
+}
+
+func remediationHint(res healthcheck.Result, remoteName string) string {
+	switch res.Name {
+	case "tcp":
+		return i18n.T("check that the remote host is up and reachable on port 22")
+	case "ssh-auth":
+		return i18n.T("check SSH_KEY/PLANET_KEY and that the key is authorized on the remote")
+	case "rclone-list":
+		return i18n.Tf("check the rclone remote config with `rclone config show %s`", remoteName)
+	case "disk-free":
+		return i18n.T("free up space on the remote before retrying")
+	case "clock-skew":
+		return i18n.T("fix NTP on the remote -- large clock skew makes mtime-based sync unreliable")
+	default:
+		return i18n.T("check the remote and try again")
+	}
+}
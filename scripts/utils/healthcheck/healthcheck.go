@@ -0,0 +1,65 @@
+// Package healthcheck provides container-runtime-style probes for the
+// remote endpoint pxsync talks to: is the host reachable, does the SSH key
+// still authenticate, can rclone list the remote, how much free space is
+// left, and is the remote's clock skewed enough to confuse mtime-based
+// change detection.
+package healthcheck
+
+import (
+	"context"
+	"time"
+)
+
+// Result is one probe's outcome at a point in time.
+type Result struct {
+	Name    string
+	OK      bool
+	Latency time.Duration
+	Detail  string
+	Err     error
+}
+
+// Prober is a single health check. Implementations should respect ctx's
+// deadline rather than blocking indefinitely.
+type Prober interface {
+	Name() string
+	Probe(ctx context.Context) Result
+}
+
+// Runner runs a fixed set of probes, either one at a time or as a batch.
+type Runner struct {
+	Probes  []Prober
+	Timeout time.Duration
+}
+
+// NewRunner builds a Runner with a sane per-probe timeout.
+func NewRunner(probes []Prober) *Runner {
+	return &Runner{Probes: probes, Timeout: 5 * time.Second}
+}
+
+// RunOnce probes everything and returns one Result per prober, in order.
+func (r *Runner) RunOnce(ctx context.Context) []Result {
+	results := make([]Result, len(r.Probes))
+	for i, p := range r.Probes {
+		probeCtx, cancel := context.WithTimeout(ctx, r.Timeout)
+		start := time.Now()
+		res := p.Probe(probeCtx)
+		cancel()
+		res.Name = p.Name()
+		if res.Latency == 0 {
+			res.Latency = time.Since(start)
+		}
+		results[i] = res
+	}
+	return results
+}
+
+// AllOK reports whether every result in a batch succeeded.
+func AllOK(results []Result) bool {
+	for _, r := range results {
+		if !r.OK {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,37 @@
+package healthcheck
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ServeMetrics starts a blocking HTTP server exposing the latest probe
+// results in Prometheus text exposition format at /metrics. getResults is
+// called on every scrape, so it should be cheap and non-blocking (read a
+// mutex-protected slice, not run the probes themselves).
+func ServeMetrics(addr string, getResults func() []Result) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+		var b strings.Builder
+		b.WriteString("# HELP pxsync_probe_up Whether the named health probe last succeeded (1) or failed (0).\n")
+		b.WriteString("# TYPE pxsync_probe_up gauge\n")
+		b.WriteString("# HELP pxsync_probe_latency_seconds Latency of the last run of the named health probe.\n")
+		b.WriteString("# TYPE pxsync_probe_latency_seconds gauge\n")
+
+		for _, res := range getResults() {
+			up := 0
+			if res.OK {
+				up = 1
+			}
+			fmt.Fprintf(&b, "pxsync_probe_up{probe=%q} %d\n", res.Name, up)
+			fmt.Fprintf(&b, "pxsync_probe_latency_seconds{probe=%q} %f\n", res.Name, res.Latency.Seconds())
+		}
+
+		_, _ = w.Write([]byte(b.String()))
+	})
+
+	return http.ListenAndServe(addr, mux)
+}
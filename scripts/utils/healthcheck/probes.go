@@ -0,0 +1,180 @@
+package healthcheck
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TCPProbe dials host:port and reports whether the connection succeeds.
+type TCPProbe struct {
+	Host string
+	Port int
+}
+
+func (p TCPProbe) Name() string { return "tcp" }
+
+func (p TCPProbe) Probe(ctx context.Context) Result {
+	addr := net.JoinHostPort(p.Host, strconv.Itoa(p.Port))
+	var d net.Dialer
+	start := time.Now()
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	latency := time.Since(start)
+	if err != nil {
+		return Result{OK: false, Latency: latency, Err: fmt.Errorf("dial %s: %w", addr, err)}
+	}
+	_ = conn.Close()
+	return Result{OK: true, Latency: latency, Detail: "connected to " + addr}
+}
+
+// SSHAuthProbe runs a trivial batch-mode SSH command to confirm the key
+// still authenticates without a password prompt.
+type SSHAuthProbe struct {
+	Host string
+	User string
+	Key  string
+}
+
+func (p SSHAuthProbe) Name() string { return "ssh-auth" }
+
+func (p SSHAuthProbe) Probe(ctx context.Context) Result {
+	cmd := exec.CommandContext(ctx, "ssh",
+		"-i", p.Key,
+		"-o", "BatchMode=yes",
+		"-o", "ConnectTimeout=5",
+		p.User+"@"+p.Host,
+		"true",
+	)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	start := time.Now()
+	err := cmd.Run()
+	latency := time.Since(start)
+	if err != nil {
+		return Result{OK: false, Latency: latency, Err: fmt.Errorf("ssh auth failed: %w: %s", err, strings.TrimSpace(stderr.String()))}
+	}
+	return Result{OK: true, Latency: latency, Detail: "key authenticates"}
+}
+
+// RcloneListProbe confirms rclone can still list the remote's root.
+type RcloneListProbe struct {
+	Remote string
+}
+
+func (p RcloneListProbe) Name() string { return "rclone-list" }
+
+func (p RcloneListProbe) Probe(ctx context.Context) Result {
+	cmd := exec.CommandContext(ctx, "rclone", "lsd", p.Remote+":")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	start := time.Now()
+	err := cmd.Run()
+	latency := time.Since(start)
+	if err != nil {
+		return Result{OK: false, Latency: latency, Err: fmt.Errorf("rclone lsd failed: %w: %s", err, strings.TrimSpace(stderr.String()))}
+	}
+	return Result{OK: true, Latency: latency, Detail: "remote listable"}
+}
+
+// DiskFreeProbe reports the remote's free space via `rclone about --json`
+// and fails if free space drops to zero.
+type DiskFreeProbe struct {
+	Remote string
+}
+
+func (p DiskFreeProbe) Name() string { return "disk-free" }
+
+func (p DiskFreeProbe) Probe(ctx context.Context) Result {
+	cmd := exec.CommandContext(ctx, "rclone", "about", p.Remote+":", "--json")
+	start := time.Now()
+	out, err := cmd.Output()
+	latency := time.Since(start)
+	if err != nil {
+		return Result{OK: false, Latency: latency, Err: fmt.Errorf("rclone about failed: %w", err)}
+	}
+
+	var about struct {
+		Free int64 `json:"free"`
+	}
+	if err := json.Unmarshal(out, &about); err != nil {
+		return Result{OK: false, Latency: latency, Err: fmt.Errorf("failed to parse rclone about output: %w", err)}
+	}
+	if about.Free <= 0 {
+		return Result{OK: false, Latency: latency, Detail: "no free space reported", Err: fmt.Errorf("remote reports 0 bytes free")}
+	}
+
+	return Result{OK: true, Latency: latency, Detail: formatBytes(about.Free) + " free"}
+}
+
+// ClockSkewProbe compares the remote's clock (read over SSH) against the
+// local clock, failing once the skew is large enough to make rclone's
+// mtime-based comparisons unreliable.
+type ClockSkewProbe struct {
+	Host     string
+	User     string
+	Key      string
+	MaxSkew  time.Duration
+	LocalNow func() time.Time
+}
+
+func (p ClockSkewProbe) Name() string { return "clock-skew" }
+
+func (p ClockSkewProbe) Probe(ctx context.Context) Result {
+	maxSkew := p.MaxSkew
+	if maxSkew == 0 {
+		maxSkew = 5 * time.Second
+	}
+	now := p.LocalNow
+	if now == nil {
+		now = time.Now
+	}
+
+	cmd := exec.CommandContext(ctx, "ssh",
+		"-i", p.Key,
+		"-o", "BatchMode=yes",
+		"-o", "ConnectTimeout=5",
+		p.User+"@"+p.Host,
+		"date", "-u", "+%s",
+	)
+	start := time.Now()
+	out, err := cmd.Output()
+	latency := time.Since(start)
+	if err != nil {
+		return Result{OK: false, Latency: latency, Err: fmt.Errorf("failed to read remote clock: %w", err)}
+	}
+
+	remoteUnix, err := strconv.ParseInt(strings.TrimSpace(string(out)), 10, 64)
+	if err != nil {
+		return Result{OK: false, Latency: latency, Err: fmt.Errorf("failed to parse remote clock output: %w", err)}
+	}
+
+	skew := now().UTC().Sub(time.Unix(remoteUnix, 0).UTC())
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > maxSkew {
+		return Result{OK: false, Latency: latency, Detail: skew.String() + " skew", Err: fmt.Errorf("clock skew %s exceeds %s", skew, maxSkew)}
+	}
+	return Result{OK: true, Latency: latency, Detail: skew.String() + " skew"}
+}
+
+func formatBytes(b int64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+	div, exp := int64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(b)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,93 @@
+// Package i18n wraps a gettext runtime so pxsync's TUI and CLI output can
+// be translated. Strings are looked up by their English msgid, compiled
+// translations are embedded at build time from po/, and the active
+// locale is picked up from LANG/LC_ALL unless overridden with --lang.
+package i18n
+
+import (
+	"embed"
+	"io/fs"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/leonelquinteros/gotext"
+)
+
+//go:embed po
+var embeddedPoFS embed.FS
+
+// poFS is rooted at po/ itself (rather than its parent), so a locale's
+// files sit at "<lang>/LC_MESSAGES/default.mo", matching what
+// gotext.NewLocaleFS expects.
+var poFS, _ = fs.Sub(embeddedPoFS, "po")
+
+const domain = "default"
+
+var (
+	mu     sync.RWMutex
+	locale *gotext.Locale
+)
+
+func init() {
+	SetLocale(localeFromEnv())
+}
+
+// localeFromEnv mirrors gettext's own precedence: LC_ALL wins over LANG.
+// Both arrive in POSIX form ("en_US.UTF-8"); we only care about the
+// two-letter language code.
+func localeFromEnv() string {
+	for _, name := range []string{"LC_ALL", "LANG"} {
+		if v := os.Getenv(name); v != "" {
+			return normalize(v)
+		}
+	}
+	return "en"
+}
+
+func normalize(lang string) string {
+	if i := strings.IndexAny(lang, ".@"); i >= 0 {
+		lang = lang[:i]
+	}
+	if i := strings.Index(lang, "_"); i >= 0 {
+		lang = lang[:i]
+	}
+	return lang
+}
+
+// SetLocale switches the active language. Called once at startup from
+// LANG/LC_ALL, and again if --lang is passed, which takes precedence.
+func SetLocale(lang string) {
+	l := gotext.NewLocaleFS(lang, poFS)
+	l.AddDomain(domain)
+	l.SetDomain(domain)
+
+	mu.Lock()
+	locale = l
+	mu.Unlock()
+}
+
+// T translates msgid into the active locale, falling back to msgid
+// itself when no translation is loaded for it.
+//
+// Get is indirected through a local func value rather than called
+// directly: go vet's printf analysis treats gotext.Locale.Get as a
+// printf wrapper (it forwards to fmt.Sprintf internally) and flags any
+// direct call passing a non-constant msgid, even though T never takes
+// format arguments.
+func T(msgid string) string {
+	mu.RLock()
+	l := locale
+	mu.RUnlock()
+	lookup := l.Get
+	return lookup(msgid)
+}
+
+// Tf translates a printf-style msgid, substituting args the way
+// fmt.Sprintf would.
+func Tf(msgid string, args ...interface{}) string {
+	mu.RLock()
+	l := locale
+	mu.RUnlock()
+	return l.Get(msgid, args...)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pixelatedempathy/pixelated/scripts/utils/i18n"
+)
+
+// flags is populated by cobra from the persistent flags below and overlaid
+// onto defaultConfig() by each subcommand's RunE.
+var flags globalFlags
+
+func newRootCmd() *cobra.Command {
+	root := &cobra.Command{
+		Use:           "pxsync",
+		Short:         "Sync the pixelated repository to/from a remote over rclone+sftp",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		// PersistentPreRunE runs after flags are parsed but before any
+		// subcommand's RunE, so --lang takes effect before the first
+		// byte of TUI or status output is produced.
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if flags.lang != "" {
+				i18n.SetLocale(flags.lang)
+			}
+			return nil
+		},
+	}
+
+	root.PersistentFlags().StringVar(&flags.remote, "remote", "", "rclone remote name (default \"planet\")")
+	root.PersistentFlags().StringVar(&flags.host, "host", "", "remote SSH host")
+	root.PersistentFlags().StringVar(&flags.user, "user", "", "remote SSH user")
+	root.PersistentFlags().StringVar(&flags.key, "key", "", "path to the SSH private key")
+	root.PersistentFlags().StringVar(&flags.local, "local", "", "local directory to sync")
+	root.PersistentFlags().StringVar(&flags.excludeFile, "exclude-file", "", "path to the rclone exclusion list")
+	root.PersistentFlags().StringVar(&flags.lang, "lang", "", "override the UI language (default: from LANG/LC_ALL)")
+	root.PersistentFlags().DurationVar(&flags.healthInterval, "health-interval", 0, "remote health-check interval (default 30s)")
+
+	root.AddCommand(
+		newSyncCmd(),
+		newConfigCmd(),
+		newWatchCmd(),
+		newDryRunCmd(),
+		newRestoreCmd(),
+		newStatusCmd(),
+		newBisyncCmd(),
+	)
+
+	return root
+}
+
+func main() {
+	if err := newRootCmd().Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "pxsync: %v\n", err)
+		os.Exit(1)
+	}
+}
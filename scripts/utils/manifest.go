@@ -0,0 +1,279 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/zeebo/blake3"
+)
+
+// fullHashThreshold is the size below which buildManifest hashes a file's
+// entire contents rather than just its first partialHashSize bytes.
+const fullHashThreshold = 8 * 1024 * 1024 // 8MiB
+
+const partialHashSize = 64 * 1024 // 64KiB
+
+// manifestEntry is what we need to tell, between two runs, whether a file
+// changed without asking the remote.
+type manifestEntry struct {
+	Size        int64     `json:"size"`
+	ModTime     time.Time `json:"modTime"`
+	PartialHash string    `json:"partialHash"`
+	FullHash    string    `json:"fullHash,omitempty"`
+}
+
+// manifest is the full snapshot of a local tree, keyed by path relative to
+// cfg.localDir, plus whether the sync run that consumed it finished
+// cleanly. A dirty flag here means the next run can't trust this snapshot
+// enough to skip rclone's own listing, so the --files-from optimization is
+// skipped until a clean run re-establishes a trustworthy baseline.
+type manifest struct {
+	Entries            map[string]manifestEntry `json:"entries"`
+	SyncCompletedClean bool                     `json:"syncCompletedClean"`
+}
+
+func manifestPath(cfg config) (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve cache directory: %w", err)
+	}
+	return filepath.Join(cacheDir, "pxsync", fmt.Sprintf("manifest-%s.json", cfg.remoteName)), nil
+}
+
+func loadManifest(cfg config) (manifest, error) {
+	empty := manifest{Entries: map[string]manifestEntry{}}
+
+	path, err := manifestPath(cfg)
+	if err != nil {
+		return empty, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return empty, nil
+	}
+	if err != nil {
+		return empty, fmt.Errorf("failed to read manifest %s: %w", path, err)
+	}
+
+	var m manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return empty, fmt.Errorf("failed to parse manifest %s: %w", path, err)
+	}
+	if m.Entries == nil {
+		m.Entries = map[string]manifestEntry{}
+	}
+	return m, nil
+}
+
+func saveManifest(cfg config, m manifest) error {
+	path, err := manifestPath(cfg)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create manifest directory: %w", err)
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write manifest %s: %w", path, err)
+	}
+	return nil
+}
+
+// buildManifest walks cfg.localDir in parallel across a worker pool and
+// hashes every regular file, skipping the exclusion directories the rest
+// of pxsync already knows about.
+func buildManifest(cfg config, rehash bool) (manifest, error) {
+	type walkResult struct {
+		relPath string
+		entry   manifestEntry
+		err     error
+	}
+
+	paths := make(chan string, 256)
+	results := make(chan walkResult, 256)
+
+	var workers sync.WaitGroup
+	numWorkers := runtime.NumCPU()
+	if numWorkers < 1 {
+		numWorkers = 1
+	}
+	for i := 0; i < numWorkers; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for relPath := range paths {
+				entry, err := hashFile(filepath.Join(cfg.localDir, relPath), rehash)
+				results <- walkResult{relPath: relPath, entry: entry, err: err}
+			}
+		}()
+	}
+
+	var walkErr error
+	go func() {
+		defer close(paths)
+		walkErr = filepath.Walk(cfg.localDir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return nil
+			}
+			if info.IsDir() {
+				if info.Name() == ".git" || info.Name() == "node_modules" {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			relPath, err := filepath.Rel(cfg.localDir, path)
+			if err != nil {
+				return nil
+			}
+			paths <- relPath
+			return nil
+		})
+	}()
+
+	go func() {
+		workers.Wait()
+		close(results)
+	}()
+
+	m := manifest{Entries: map[string]manifestEntry{}}
+	for res := range results {
+		if res.err != nil {
+			return m, fmt.Errorf("failed to hash %s: %w", res.relPath, res.err)
+		}
+		m.Entries[res.relPath] = res.entry
+	}
+	if walkErr != nil {
+		return m, fmt.Errorf("failed to walk %s: %w", cfg.localDir, walkErr)
+	}
+
+	return m, nil
+}
+
+func hashFile(path string, rehash bool) (manifestEntry, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return manifestEntry{}, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return manifestEntry{}, err
+	}
+	defer func() { _ = f.Close() }()
+
+	partial := blake3.New()
+	if _, err := io.CopyN(partial, f, partialHashSize); err != nil && err != io.EOF {
+		return manifestEntry{}, err
+	}
+
+	entry := manifestEntry{
+		Size:        info.Size(),
+		ModTime:     info.ModTime(),
+		PartialHash: hex.EncodeToString(partial.Sum(nil)),
+	}
+
+	if rehash || info.Size() <= fullHashThreshold {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return manifestEntry{}, err
+		}
+		full := blake3.New()
+		if _, err := io.Copy(full, f); err != nil {
+			return manifestEntry{}, err
+		}
+		entry.FullHash = hex.EncodeToString(full.Sum(nil))
+	}
+
+	return entry, nil
+}
+
+// diffManifest compares a freshly built manifest against the previous run
+// and returns the relative paths that changed (new, resized, rehashed, or
+// with a newer mtime) along with the total bytes those files account for.
+func diffManifest(prev, curr manifest) (changed []string, totalBytes int64) {
+	changed = []string{}
+	for relPath, currEntry := range curr.Entries {
+		prevEntry, ok := prev.Entries[relPath]
+		if !ok || entryChanged(prevEntry, currEntry) {
+			changed = append(changed, relPath)
+			totalBytes += currEntry.Size
+		}
+	}
+	return changed, totalBytes
+}
+
+func entryChanged(prev, curr manifestEntry) bool {
+	if prev.Size != curr.Size || !prev.ModTime.Equal(curr.ModTime) {
+		return true
+	}
+	if curr.FullHash != "" && prev.FullHash != "" {
+		return curr.FullHash != prev.FullHash
+	}
+	return curr.PartialHash != prev.PartialHash
+}
+
+// summarizeChanges builds the current manifest, diffs it against the last
+// clean run and reports back the files pxsync actually needs to transfer
+// so the TUI can show real numbers before touching the network.
+func summarizeChanges(cfg config, rehash bool) tea.Cmd {
+	return func() tea.Msg {
+		prev, err := loadManifest(cfg)
+		if err != nil {
+			return errMsg{fmt.Errorf("failed to load previous manifest: %w", err)}
+		}
+
+		curr, err := buildManifest(cfg, rehash)
+		if err != nil {
+			return errMsg{fmt.Errorf("failed to build manifest: %w", err)}
+		}
+
+		skippedOptimization := len(prev.Entries) > 0 && !prev.SyncCompletedClean
+		changed := []string{}
+		var bytesToTransfer int64
+		if skippedOptimization {
+			for relPath, entry := range curr.Entries {
+				changed = append(changed, relPath)
+				bytesToTransfer += entry.Size
+			}
+		} else {
+			changed, bytesToTransfer = diffManifest(prev, curr)
+		}
+
+		// Persist as dirty; the sync-completion handler flips this to true
+		// once the transfer this manifest describes actually succeeds.
+		curr.SyncCompletedClean = false
+		if err := saveManifest(cfg, curr); err != nil {
+			return errMsg{fmt.Errorf("failed to save manifest: %w", err)}
+		}
+
+		return manifestSummaryMsg{
+			filesToTransfer:     changed,
+			bytesToTransfer:     bytesToTransfer,
+			skippedOptimization: skippedOptimization,
+		}
+	}
+}
+
+// markManifestClean flips the on-disk manifest's clean flag once a sync
+// job it described has finished successfully.
+func markManifestClean(cfg config) error {
+	m, err := loadManifest(cfg)
+	if err != nil {
+		return err
+	}
+	m.SyncCompletedClean = true
+	return saveManifest(cfg, m)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEntryChangedDetectsSizeAndModTimeDrift(t *testing.T) {
+	base := manifestEntry{Size: 100, ModTime: time.Unix(1000, 0), PartialHash: "abc"}
+
+	cases := []struct {
+		name string
+		curr manifestEntry
+		want bool
+	}{
+		{"identical", base, false},
+		{"size changed", manifestEntry{Size: 200, ModTime: base.ModTime, PartialHash: "abc"}, true},
+		{"modtime changed", manifestEntry{Size: 100, ModTime: time.Unix(2000, 0), PartialHash: "abc"}, true},
+		{"partial hash changed, same size/modtime", manifestEntry{Size: 100, ModTime: base.ModTime, PartialHash: "def"}, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := entryChanged(base, tc.curr); got != tc.want {
+				t.Errorf("entryChanged(%+v, %+v) = %v, want %v", base, tc.curr, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEntryChangedPrefersFullHashWhenBothSidesHaveOne(t *testing.T) {
+	prev := manifestEntry{Size: 100, ModTime: time.Unix(1000, 0), PartialHash: "same", FullHash: "full-a"}
+	curr := manifestEntry{Size: 100, ModTime: time.Unix(1000, 0), PartialHash: "same", FullHash: "full-b"}
+
+	if !entryChanged(prev, curr) {
+		t.Fatal("entryChanged() = false, want true: full hashes differ even though size/modtime/partial hash match")
+	}
+}
+
+func TestDiffManifestFindsNewAndChangedFiles(t *testing.T) {
+	modTime := time.Unix(1000, 0)
+	prev := manifest{Entries: map[string]manifestEntry{
+		"unchanged.txt": {Size: 10, ModTime: modTime, PartialHash: "a"},
+		"changed.txt":   {Size: 10, ModTime: modTime, PartialHash: "a"},
+	}}
+	curr := manifest{Entries: map[string]manifestEntry{
+		"unchanged.txt": {Size: 10, ModTime: modTime, PartialHash: "a"},
+		"changed.txt":   {Size: 20, ModTime: modTime, PartialHash: "b"},
+		"new.txt":       {Size: 5, ModTime: modTime, PartialHash: "c"},
+	}}
+
+	changed, totalBytes := diffManifest(prev, curr)
+
+	want := map[string]bool{"changed.txt": true, "new.txt": true}
+	if len(changed) != len(want) {
+		t.Fatalf("diffManifest() changed = %v, want exactly %v", changed, want)
+	}
+	for _, relPath := range changed {
+		if !want[relPath] {
+			t.Errorf("diffManifest() unexpectedly flagged %q as changed", relPath)
+		}
+	}
+	if totalBytes != 25 {
+		t.Errorf("diffManifest() totalBytes = %d, want 25", totalBytes)
+	}
+}
+
+func TestDiffManifestEmptyWhenNothingChanged(t *testing.T) {
+	modTime := time.Unix(1000, 0)
+	m := manifest{Entries: map[string]manifestEntry{
+		"a.txt": {Size: 10, ModTime: modTime, PartialHash: "a"},
+	}}
+
+	changed, totalBytes := diffManifest(m, m)
+
+	if len(changed) != 0 {
+		t.Errorf("diffManifest() changed = %v, want empty", changed)
+	}
+	if totalBytes != 0 {
+		t.Errorf("diffManifest() totalBytes = %d, want 0", totalBytes)
+	}
+}
@@ -0,0 +1,1167 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/progress"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/pixelatedempathy/pixelated/scripts/utils/healthcheck"
+	"github.com/pixelatedempathy/pixelated/scripts/utils/i18n"
+)
+
+// Styles using lipgloss (Bubble Tea's styling library)
+var (
+	pinkStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("212"))
+	purpleStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("57"))
+	greenStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+	yellowStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("11"))
+	redStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	whiteStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("255"))
+	boldStyle   = lipgloss.NewStyle().Bold(true)
+
+	doubleBoxStyle = lipgloss.NewStyle().
+			BorderStyle(lipgloss.DoubleBorder()).
+			BorderForeground(lipgloss.Color("212")).
+			Padding(1, 2).
+			Margin(1, 0)
+
+	infoBoxStyle = lipgloss.NewStyle().
+			BorderStyle(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("57")).
+			Foreground(lipgloss.Color("255")).
+			Padding(1, 2).
+			Margin(1, 0)
+
+	successBoxStyle = lipgloss.NewStyle().
+			BorderStyle(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("10")).
+			Foreground(lipgloss.Color("10")).
+			Padding(1, 2).
+			Margin(1, 0)
+
+	warningBoxStyle = lipgloss.NewStyle().
+			BorderStyle(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("11")).
+			Foreground(lipgloss.Color("255")).
+			Padding(1, 2).
+			Margin(1, 0)
+
+	errorBoxStyle = lipgloss.NewStyle().
+			BorderStyle(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("9")).
+			Foreground(lipgloss.Color("255")).
+			Padding(1, 2).
+			Margin(1, 0)
+
+	headerStyle = lipgloss.NewStyle().
+			BorderStyle(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("212")).
+			Foreground(lipgloss.Color("212")).
+			Padding(1, 2).
+			Margin(1, 0)
+)
+
+var _ = []lipgloss.Style{pinkStyle, yellowStyle, redStyle, whiteStyle, boldStyle, warningBoxStyle}
+
+// rcTransfer mirrors one entry of rclone rc's core/stats "transferring" array.
+type rcTransfer struct {
+	Name       string  `json:"name"`
+	Bytes      int64   `json:"bytes"`
+	Size       int64   `json:"size"`
+	Percentage int     `json:"percentage"`
+	Speed      float64 `json:"speed"`
+	ETA        int64   `json:"eta"`
+}
+
+// rcStats mirrors the subset of rclone rc's core/stats response we care about.
+type rcStats struct {
+	Bytes        int64        `json:"bytes"`
+	TotalBytes   int64        `json:"totalBytes"`
+	Speed        float64      `json:"speed"`
+	ETA          int64        `json:"eta"`
+	Transferring []rcTransfer `json:"transferring"`
+}
+
+// rcJobStatus mirrors rclone rc's job/status response.
+type rcJobStatus struct {
+	Finished bool   `json:"finished"`
+	Success  bool   `json:"success"`
+	Error    string `json:"error"`
+	ID       int64  `json:"id"`
+}
+
+// rcDaemon supervises a `rclone rcd` subprocess and talks to it over its
+// loopback-only remote-control HTTP API.
+type rcDaemon struct {
+	cmd    *exec.Cmd
+	addr   string
+	client *http.Client
+	jobID  int64
+}
+
+// startRcDaemon launches `rclone rcd` on a free loopback port with no auth
+// (the endpoint never leaves the machine) and waits for it to come up.
+func startRcDaemon(cfg config, logFile string) (*rcDaemon, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve rc port: %w", err)
+	}
+	addr := listener.Addr().String()
+	if err := listener.Close(); err != nil {
+		return nil, fmt.Errorf("failed to release rc port: %w", err)
+	}
+
+	cmd := exec.Command("rclone", "rcd",
+		"--rc-addr="+addr,
+		"--rc-no-auth",
+		"--log-level=NOTICE",
+		"--log-file="+logFile,
+	)
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start rclone rcd: %w", err)
+	}
+
+	rc := &rcDaemon{cmd: cmd, addr: addr, client: &http.Client{Timeout: 5 * time.Second}}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, err := rc.call("core/pid", nil); err == nil {
+			return rc, nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	_ = cmd.Process.Kill()
+	return nil, fmt.Errorf("rclone rcd did not become ready at %s", addr)
+}
+
+// call issues a JSON POST to the rc endpoint and decodes the JSON response.
+func (rc *rcDaemon) call(path string, params map[string]any) (map[string]any, error) {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode rc params for %s: %w", path, err)
+	}
+
+	resp, err := rc.client.Post("http://"+rc.addr+"/"+path, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("rc call %s failed: %w", path, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("rc call %s returned %s", path, resp.Status)
+	}
+
+	var out map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("failed to decode rc response from %s: %w", path, err)
+	}
+	return out, nil
+}
+
+// startJob kicks off an async sync/copy job and records its job ID. When
+// filesToTransfer is non-nil it's passed as the filter's FilesFrom list so
+// rclone skips its own remote listing and only transfers exactly the files
+// the local manifest diff says changed.
+func (rc *rcDaemon) startJob(cfg config, filesToTransfer []string) error {
+	filter := map[string]any{"ExcludeFrom": []string{cfg.exclusionFile}}
+	if filesToTransfer != nil {
+		filter["FilesFrom"] = filesToTransfer
+	}
+
+	out, err := rc.call("sync/copy", map[string]any{
+		"srcFs":       cfg.localDir,
+		"dstFs":       cfg.remoteName + ":" + cfg.remoteBasePath,
+		"_async":      true,
+		"_config":     map[string]any{"Transfers": 8, "Checkers": 4, "FastList": true},
+		"_filter":     filter,
+		"createEmpty": true,
+	})
+	if err != nil {
+		return err
+	}
+
+	id, ok := out["jobid"].(float64)
+	if !ok {
+		return fmt.Errorf("rc sync/copy did not return a jobid: %v", out)
+	}
+	rc.jobID = int64(id)
+	return nil
+}
+
+// stats polls core/stats for the current transfer snapshot.
+func (rc *rcDaemon) stats() (rcStats, error) {
+	var stats rcStats
+	out, err := rc.call("core/stats", map[string]any{"group": fmt.Sprintf("job/%d", rc.jobID)})
+	if err != nil {
+		return stats, err
+	}
+
+	raw, err := json.Marshal(out)
+	if err != nil {
+		return stats, fmt.Errorf("failed to re-encode core/stats response: %w", err)
+	}
+	if err := json.Unmarshal(raw, &stats); err != nil {
+		return stats, fmt.Errorf("failed to decode core/stats response: %w", err)
+	}
+	return stats, nil
+}
+
+// jobStatus polls job/status for the job's terminal state.
+func (rc *rcDaemon) jobStatus() (rcJobStatus, error) {
+	var status rcJobStatus
+	out, err := rc.call("job/status", map[string]any{"jobid": rc.jobID})
+	if err != nil {
+		return status, err
+	}
+
+	raw, err := json.Marshal(out)
+	if err != nil {
+		return status, fmt.Errorf("failed to re-encode job/status response: %w", err)
+	}
+	if err := json.Unmarshal(raw, &status); err != nil {
+		return status, fmt.Errorf("failed to decode job/status response: %w", err)
+	}
+	return status, nil
+}
+
+// shutdown asks rclone to exit cleanly over the rc API, falling back to
+// killing the process if it doesn't respond.
+func (rc *rcDaemon) shutdown() {
+	if rc == nil || rc.cmd == nil || rc.cmd.Process == nil {
+		return
+	}
+	if _, err := rc.call("core/quit", nil); err != nil {
+		_ = rc.cmd.Process.Kill()
+		return
+	}
+	_ = rc.cmd.Wait()
+}
+
+// Model represents the application state
+type model struct {
+	config       config
+	state        string // "init", "checking", "configuring", "generating", "summarizing", "syncing", "bisync", "degraded", "done", "error"
+	messages     []string
+	err          error
+	width        int
+	height       int
+	spinnerFrame int
+
+	exclusionCount   int
+	gitCount         int
+	nodeModulesCount int
+
+	rc          *rcDaemon
+	progressBar progress.Model
+	transfers   []rcTransfer
+	bytesDone   int64
+	bytesTotal  int64
+	speed       float64
+	eta         time.Duration
+
+	bisyncMode      bool
+	conflicts       []bisyncConflict
+	conflictCursor  int
+	conflictChoices map[string]bisyncResolution
+
+	rehash              bool
+	filesToTransfer     []string
+	bytesToTransfer     int64
+	skippedOptimization bool
+
+	remoteOutcome remoteOutcome
+
+	healthRunner              *healthcheck.Runner
+	healthResults             []healthcheck.Result
+	healthConsecutiveFailures int
+	degraded                  bool
+	preDegradeState           string
+}
+
+func initialModel(cfg config) model {
+	return initialModelMode(cfg, false, false)
+}
+
+func initialModelMode(cfg config, bisyncMode, rehash bool) model {
+	return model{
+		config:       cfg,
+		state:        "init",
+		messages:     []string{},
+		progressBar:  progress.New(progress.WithDefaultGradient()),
+		bisyncMode:   bisyncMode,
+		rehash:       rehash,
+		healthRunner: newHealthRunner(cfg),
+	}
+}
+
+// Messages
+type (
+	errMsg            struct{ err error }
+	statusMsg         struct{ msg string }
+	stateChangeMsg    struct{ state string }
+	spinnerTickMsg    struct{}
+	exclusionCountMsg struct{ git, nodeModules int }
+	rcStartedMsg      struct{ rc *rcDaemon }
+	overallStatsMsg   struct {
+		bytesDone  int64
+		bytesTotal int64
+		speed      float64
+		eta        time.Duration
+	}
+	transferMsg struct{ transfers []rcTransfer }
+	jobDoneMsg  struct {
+		success bool
+		err     error
+	}
+	rcPollTickMsg      struct{}
+	bisyncConflictsMsg struct{ conflicts []bisyncConflict }
+	bisyncDoneMsg      struct{ err error }
+	manifestSummaryMsg struct {
+		filesToTransfer     []string
+		bytesToTransfer     int64
+		skippedOptimization bool
+	}
+	healthMsg struct{ results []healthcheck.Result }
+
+	// remoteCheckMsg, remoteConfiguredMsg and remoteTestMsg carry the
+	// untranslated outcome of checkRemote/configureRemote/testRemote.
+	// handleStateTransitions drives the "checking"/"configuring" states
+	// off these, never off the (translated) text appended to m.messages.
+	remoteCheckMsg struct {
+		outcome remoteOutcome
+		remote  string
+	}
+	remoteConfiguredMsg struct{ remote string }
+	remoteTestMsg       struct {
+		ok     bool
+		remote string
+	}
+)
+
+// remoteOutcome is a machine-readable result code for the remote
+// check/configure/test commands -- locale-independent, unlike the
+// i18n.T/i18n.Tf strings those commands also produce for display.
+type remoteOutcome int
+
+const (
+	remoteOutcomeNone remoteOutcome = iota
+	remoteOutcomeReady
+	remoteOutcomeReconfigure
+	remoteOutcomeNeedsConfig
+	remoteOutcomeConfigured
+	remoteOutcomeTestPassed
+	remoteOutcomeTestFailed
+)
+
+// Spinner frames
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+func (m model) Init() tea.Cmd {
+	return tea.Batch(
+		checkRclone,
+		checkSSHKey(m.config),
+		spinnerTick,
+		healthTick(m.healthRunner, m.config.healthInterval),
+	)
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.progressBar.Width = msg.Width - 8
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.state == "bisync" && m.conflictCursor < len(m.conflicts) {
+			switch msg.String() {
+			case "l":
+				return m.chooseConflictResolution(resolutionLocalWins)
+			case "r":
+				return m.chooseConflictResolution(resolutionRemoteWins)
+			case "b":
+				return m.chooseConflictResolution(resolutionKeepBoth)
+			}
+		}
+		switch msg.String() {
+		case "ctrl+c", "q":
+			m.rc.shutdown()
+			return m, tea.Quit
+		}
+		return m, nil
+
+	case spinnerTickMsg:
+		m.spinnerFrame = (m.spinnerFrame + 1) % len(spinnerFrames)
+		if m.state == "checking" || m.state == "configuring" || m.state == "generating" || m.state == "summarizing" || m.state == "syncing" || m.state == "bisync" {
+			return m, spinnerTick
+		}
+		return m, nil
+
+	case errMsg:
+		m.err = msg.err
+		m.state = "error"
+		return m, nil
+
+	case statusMsg:
+		m.messages = append(m.messages, msg.msg)
+		if len(m.messages) > 10 {
+			m.messages = m.messages[len(m.messages)-10:]
+		}
+		return m, m.handleStateTransitions()
+
+	case remoteCheckMsg:
+		m.remoteOutcome = msg.outcome
+		switch msg.outcome {
+		case remoteOutcomeReady:
+			m.messages = append(m.messages, i18n.Tf("✓ Remote '%s' already configured and connected", msg.remote))
+		case remoteOutcomeReconfigure:
+			m.messages = append(m.messages, i18n.T("⚠ Remote exists but connection failed. Reconfiguring..."))
+		case remoteOutcomeNeedsConfig:
+			m.messages = append(m.messages, i18n.Tf("Remote '%s' not found. Need to configure...", msg.remote))
+		}
+		if len(m.messages) > 10 {
+			m.messages = m.messages[len(m.messages)-10:]
+		}
+		return m, m.handleStateTransitions()
+
+	case remoteConfiguredMsg:
+		m.remoteOutcome = remoteOutcomeConfigured
+		m.messages = append(m.messages, i18n.Tf("✓ Remote '%s' configured successfully", msg.remote))
+		if len(m.messages) > 10 {
+			m.messages = m.messages[len(m.messages)-10:]
+		}
+		return m, m.handleStateTransitions()
+
+	case remoteTestMsg:
+		if msg.ok {
+			m.remoteOutcome = remoteOutcomeTestPassed
+			m.messages = append(m.messages, i18n.T("✓ Remote connection test successful"))
+		} else {
+			m.remoteOutcome = remoteOutcomeTestFailed
+			m.messages = append(m.messages, i18n.T("⚠ Remote configured but connection test failed"))
+		}
+		if len(m.messages) > 10 {
+			m.messages = m.messages[len(m.messages)-10:]
+		}
+		return m, m.handleStateTransitions()
+
+	case stateChangeMsg:
+		m.state = msg.state
+		return m, m.handleStateTransitions()
+
+	case exclusionCountMsg:
+		m.gitCount = msg.git
+		m.nodeModulesCount = msg.nodeModules
+		m.exclusionCount = msg.git + msg.nodeModules
+		return m, m.handleStateTransitions()
+
+	case rcStartedMsg:
+		m.rc = msg.rc
+		return m, pollRc(m.rc)
+
+	case overallStatsMsg:
+		m.bytesDone = msg.bytesDone
+		m.bytesTotal = msg.bytesTotal
+		m.speed = msg.speed
+		m.eta = msg.eta
+		var cmd tea.Cmd
+		if m.bytesTotal > 0 {
+			cmd = m.progressBar.SetPercent(float64(m.bytesDone) / float64(m.bytesTotal))
+		}
+		return m, cmd
+
+	case transferMsg:
+		m.transfers = msg.transfers
+		return m, nil
+
+	case jobDoneMsg:
+		m.rc.shutdown()
+		m.rc = nil
+		if msg.err != nil {
+			m.err = msg.err
+			m.state = "error"
+			return m, nil
+		}
+		if !msg.success {
+			m.err = fmt.Errorf("rclone reported sync job failed")
+			m.state = "error"
+			return m, nil
+		}
+		if !m.bisyncMode {
+			if err := markManifestClean(m.config); err != nil {
+				m.messages = append(m.messages, i18n.Tf("⚠ failed to mark manifest clean: %v", err))
+			}
+		}
+		m.state = "done"
+		return m, nil
+
+	case rcPollTickMsg:
+		if m.rc == nil {
+			return m, nil
+		}
+		return m, pollRc(m.rc)
+
+	case healthMsg:
+		return m.handleHealth(msg)
+
+	case manifestSummaryMsg:
+		m.filesToTransfer = msg.filesToTransfer
+		m.bytesToTransfer = msg.bytesToTransfer
+		m.skippedOptimization = msg.skippedOptimization
+		return m, tea.Sequence(
+			func() tea.Msg {
+				time.Sleep(300 * time.Millisecond)
+				return stateChangeMsg{"syncing"}
+			},
+			startSync(m.config, m.filesToTransfer),
+		)
+
+	case bisyncConflictsMsg:
+		m.conflicts = msg.conflicts
+		m.conflictCursor = 0
+		m.conflictChoices = map[string]bisyncResolution{}
+		return m, nil
+
+	case bisyncDoneMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			m.state = "error"
+			return m, nil
+		}
+		m.state = "done"
+		return m, nil
+
+	default:
+		return m, nil
+	}
+}
+
+// handleHealth updates the latest probe results, tracks consecutive
+// all-failed rounds and, once degradedThreshold is hit, pauses any
+// in-flight sync and surfaces remediation hints. A fully-healthy round
+// while degraded resumes the workflow from the "checking" state.
+func (m model) handleHealth(msg healthMsg) (tea.Model, tea.Cmd) {
+	m.healthResults = msg.results
+
+	if healthcheck.AllOK(msg.results) {
+		m.healthConsecutiveFailures = 0
+		if m.degraded {
+			m.degraded = false
+			m.state = "checking"
+			return m, tea.Sequence(
+				func() tea.Msg { return stateChangeMsg{"checking"} },
+				checkRemote(m.config),
+				healthTick(m.healthRunner, m.config.healthInterval),
+			)
+		}
+		return m, healthTick(m.healthRunner, m.config.healthInterval)
+	}
+
+	m.healthConsecutiveFailures++
+	if m.healthConsecutiveFailures >= degradedThreshold && !m.degraded {
+		m.degraded = true
+		m.preDegradeState = m.state
+		m.state = "degraded"
+		if m.rc != nil {
+			m.rc.shutdown()
+			m.rc = nil
+		}
+	}
+
+	return m, healthTick(m.healthRunner, m.config.healthInterval)
+}
+
+// chooseConflictResolution records the user's pick for the conflict
+// currently on screen and, once every conflict has a resolution, re-runs
+// bisync with the filters that apply them.
+func (m model) chooseConflictResolution(resolution bisyncResolution) (tea.Model, tea.Cmd) {
+	current := m.conflicts[m.conflictCursor]
+	m.conflictChoices[current.base] = resolution
+	m.conflictCursor++
+
+	if m.conflictCursor < len(m.conflicts) {
+		return m, nil
+	}
+	return m, resolveBisyncConflicts(m.config, m.conflicts, m.conflictChoices)
+}
+
+func (m model) handleStateTransitions() tea.Cmd {
+	switch m.state {
+	case "init":
+		if m.err == nil && len(m.messages) >= 2 {
+			// Both rclone and SSH key checks passed
+			return tea.Sequence(
+				func() tea.Msg { return stateChangeMsg{"checking"} },
+				checkRemote(m.config),
+			)
+		}
+	case "checking":
+		// Driven off the untranslated outcome of checkRemote, not the
+		// (translated) text it also appends to m.messages for display.
+		switch m.remoteOutcome {
+		case remoteOutcomeReady:
+			return tea.Sequence(
+				func() tea.Msg { return stateChangeMsg{"generating"} },
+				generateExclusions(m.config),
+			)
+		case remoteOutcomeReconfigure, remoteOutcomeNeedsConfig:
+			return tea.Sequence(
+				func() tea.Msg { return stateChangeMsg{"configuring"} },
+				configureRemote(m.config),
+			)
+		}
+		return nil
+	case "configuring":
+		// Driven off the untranslated outcome of configureRemote/testRemote.
+		switch m.remoteOutcome {
+		case remoteOutcomeConfigured:
+			// Just configured, now test it
+			return testRemote(m.config)
+		case remoteOutcomeTestPassed:
+			return tea.Sequence(
+				func() tea.Msg { return stateChangeMsg{"generating"} },
+				generateExclusions(m.config),
+			)
+		}
+		return nil
+	case "generating":
+		// After generating exclusions, add status message then start sync
+		if m.exclusionCount > 0 {
+			if m.bisyncMode {
+				return tea.Sequence(
+					func() tea.Msg { return generateExclusionsStatus(m.gitCount, m.nodeModulesCount) },
+					func() tea.Msg {
+						time.Sleep(200 * time.Millisecond)
+						return stateChangeMsg{"bisync"}
+					},
+					bisyncStart(m.config),
+				)
+			}
+			return tea.Sequence(
+				func() tea.Msg { return generateExclusionsStatus(m.gitCount, m.nodeModulesCount) },
+				func() tea.Msg {
+					time.Sleep(200 * time.Millisecond)
+					return stateChangeMsg{"summarizing"}
+				},
+				summarizeChanges(m.config, m.rehash),
+			)
+		}
+	}
+	return nil
+}
+
+func (m model) View() string {
+	if m.width == 0 {
+		return i18n.T("Loading...")
+	}
+
+	var s strings.Builder
+
+	// Header
+	title := doubleBoxStyle.
+		Width(m.width - 4).
+		Align(lipgloss.Center).
+		Render(
+			"🚀 Rclone SSH Sync\n" +
+				"Pixelated Repository",
+		)
+	s.WriteString(title)
+	s.WriteString("\n")
+
+	if len(m.healthResults) > 0 {
+		s.WriteString(m.healthStripView())
+		s.WriteString("\n")
+	}
+
+	// State-specific UI
+	switch m.state {
+	case "degraded":
+		s.WriteString(m.degradedView())
+		s.WriteString("\n\n")
+	case "init", "checking", "configuring", "generating", "summarizing":
+		spinner := purpleStyle.Render(spinnerFrames[m.spinnerFrame])
+		statusText := ""
+		switch m.state {
+		case "checking":
+			statusText = i18n.T("Checking remote configuration...")
+		case "configuring":
+			statusText = i18n.T("Configuring remote...")
+		case "generating":
+			statusText = i18n.Tf("Generating exclusion list... (%d found)", m.exclusionCount)
+		case "summarizing":
+			statusText = i18n.T("Hashing local files to see what changed...")
+		default:
+			statusText = i18n.T("Initializing...")
+		}
+		statusBox := headerStyle.Width(m.width - 4).Render(
+			fmt.Sprintf("%s %s", spinner, statusText),
+		)
+		s.WriteString(statusBox)
+		s.WriteString("\n")
+
+	case "syncing":
+		syncHeader := headerStyle.Width(m.width - 4).Render(
+			fmt.Sprintf("%s %s", spinnerFrames[m.spinnerFrame], i18n.T("Syncing files...")),
+		)
+		s.WriteString(syncHeader)
+		s.WriteString("\n\n")
+
+	case "bisync":
+		if len(m.conflicts) > 0 && m.conflictCursor < len(m.conflicts) {
+			s.WriteString(m.conflictPromptView())
+		} else {
+			syncHeader := headerStyle.Width(m.width - 4).Render(
+				fmt.Sprintf("%s %s", spinnerFrames[m.spinnerFrame], i18n.T("Bisyncing...")),
+			)
+			s.WriteString(syncHeader)
+		}
+		s.WriteString("\n\n")
+	}
+
+	// Status messages (last 5)
+	if len(m.messages) > 0 {
+		recentMessages := m.messages
+		if len(recentMessages) > 5 {
+			recentMessages = recentMessages[len(recentMessages)-5:]
+		}
+		box := infoBoxStyle.Width(m.width - 4).Render(
+			strings.Join(recentMessages, "\n"),
+		)
+		s.WriteString(box)
+		s.WriteString("\n")
+	}
+
+	// Exclusion count
+	if m.exclusionCount > 0 {
+		exclusionBox := infoBoxStyle.Width(m.width - 4).Render(
+			i18n.Tf("Exclusions: %d .git directories, %d node_modules directories",
+				m.gitCount, m.nodeModulesCount),
+		)
+		s.WriteString(exclusionBox)
+		s.WriteString("\n\n")
+	}
+
+	// Pre-sync summary from the manifest diff
+	if m.state == "syncing" || m.state == "done" {
+		summaryText := i18n.Tf("%d files changed, %s to transfer", len(m.filesToTransfer), formatBytes(m.bytesToTransfer))
+		if m.skippedOptimization {
+			summaryText += " " + i18n.T("(previous run didn't finish cleanly -- doing a full sync)")
+		}
+		summaryBox := infoBoxStyle.Width(m.width - 4).Render(summaryText)
+		s.WriteString(summaryBox)
+		s.WriteString("\n\n")
+	}
+
+	// Overall transfer progress
+	if m.bytesTotal > 0 {
+		overallBox := infoBoxStyle.Width(m.width - 4).Render(
+			fmt.Sprintf("%s\n%s / %s  %.1f MB/s  ETA %s",
+				m.progressBar.View(),
+				formatBytes(m.bytesDone), formatBytes(m.bytesTotal),
+				m.speed/1024/1024, formatETA(m.eta)),
+		)
+		s.WriteString(overallBox)
+		s.WriteString("\n\n")
+	}
+
+	// Per-file transfers
+	if len(m.transfers) > 0 {
+		lines := make([]string, 0, len(m.transfers))
+		for _, t := range m.transfers {
+			lines = append(lines, fmt.Sprintf("%-40s %3d%%", truncate(t.Name, 40), t.Percentage))
+		}
+		transfersBox := infoBoxStyle.Width(m.width - 4).Render(
+			i18n.T("Current transfers:") + "\n" + strings.Join(lines, "\n"),
+		)
+		s.WriteString(transfersBox)
+		s.WriteString("\n\n")
+	}
+
+	// Error display
+	if m.err != nil {
+		errorBox := errorBoxStyle.Width(m.width - 4).Render(
+			i18n.Tf("Error: %v", m.err),
+		)
+		s.WriteString(errorBox)
+		s.WriteString("\n\n")
+	}
+
+	// Success message
+	if m.state == "done" {
+		successText := i18n.T("✓ Sync completed successfully!")
+		if m.bisyncMode {
+			successText = i18n.T("✓ Bisync completed successfully!")
+		}
+		successBox := successBoxStyle.Width(m.width - 4).Render(successText)
+		s.WriteString(successBox)
+		s.WriteString("\n\n")
+	}
+
+	// Footer
+	footer := greenStyle.Render(i18n.T("Press 'q' or Ctrl+C to quit"))
+	s.WriteString(footer)
+
+	return lipgloss.NewStyle().
+		Width(m.width).
+		Height(m.height).
+		Align(lipgloss.Center, lipgloss.Center).
+		Render(s.String())
+}
+
+// healthStripView renders a single-line status strip with a colored dot
+// and latency per probe, persistent across every state.
+func (m model) healthStripView() string {
+	parts := make([]string, 0, len(m.healthResults))
+	for _, res := range m.healthResults {
+		dot := greenStyle.Render("●")
+		if !res.OK {
+			dot = redStyle.Render("●")
+		}
+		parts = append(parts, fmt.Sprintf("%s %s (%dms)", dot, res.Name, res.Latency.Milliseconds()))
+	}
+	return infoBoxStyle.Width(m.width - 4).Render(strings.Join(parts, "   "))
+}
+
+// degradedView surfaces which probes are failing and a remediation hint
+// for each, once repeated health-check failures have paused the sync.
+func (m model) degradedView() string {
+	var lines []string
+	lines = append(lines, i18n.T("Remote looks unhealthy -- sync paused."))
+	for _, res := range m.healthResults {
+		if res.OK {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("- %s: %v", res.Name, res.Err))
+		lines = append(lines, "  -> "+remediationHint(res, m.config.remoteName))
+	}
+	lines = append(lines, "", i18n.T("Will resume automatically once every probe passes again."))
+	return errorBoxStyle.Width(m.width - 4).Render(strings.Join(lines, "\n"))
+}
+
+// conflictPromptView renders the current bisync conflict and the
+// local-wins/remote-wins/keep-both choices the user can pick between.
+func (m model) conflictPromptView() string {
+	current := m.conflicts[m.conflictCursor]
+	prompt := i18n.Tf(
+		"Conflict %d/%d: %s\n\n[l] local wins   [r] remote wins   [b] keep both",
+		m.conflictCursor+1, len(m.conflicts), current.base,
+	)
+	return warningBoxStyle.Width(m.width - 4).Render(prompt)
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-1] + "…"
+}
+
+func formatBytes(b int64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+	div, exp := int64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(b)/float64(div), "KMGTPE"[exp])
+}
+
+func formatETA(d time.Duration) string {
+	if d <= 0 {
+		return "-"
+	}
+	return d.Round(time.Second).String()
+}
+
+// Commands
+func checkRclone() tea.Msg {
+	_, err := exec.LookPath("rclone")
+	if err != nil {
+		return errMsg{errors.New(i18n.T("rclone is not installed. Please install it first: https://rclone.org/install/"))}
+	}
+	return statusMsg{i18n.T("✓ rclone found")}
+}
+
+func checkSSHKey(cfg config) tea.Cmd {
+	return func() tea.Msg {
+		if cfg.sshKey == "" {
+			return errMsg{errors.New(i18n.T("ssh key not found. please set SSH_KEY or PLANET_KEY environment variable"))}
+		}
+		if _, err := os.Stat(cfg.sshKey); os.IsNotExist(err) {
+			return errMsg{errors.New(i18n.Tf("ssh key file not found: %s", cfg.sshKey))}
+		}
+		return statusMsg{i18n.Tf("✓ Using SSH key: %s", cfg.sshKey)}
+	}
+}
+
+func checkRemote(cfg config) tea.Cmd {
+	return func() tea.Msg {
+		// Check if remote exists
+		cmd := exec.Command("rclone", "listremotes")
+		output, err := cmd.Output()
+		if err != nil {
+			return remoteCheckMsg{outcome: remoteOutcomeNeedsConfig, remote: cfg.remoteName}
+		}
+
+		remotePattern := cfg.remoteName + ":"
+		if strings.Contains(string(output), remotePattern) {
+			// Test connection
+			testCmd := exec.Command("rclone", "lsd", remotePattern)
+			testCmd.Stderr = nil
+			testCmd.Stdout = nil
+			if err := testCmd.Run(); err == nil {
+				return remoteCheckMsg{outcome: remoteOutcomeReady, remote: cfg.remoteName}
+			}
+			// Trigger reconfiguration
+			return remoteCheckMsg{outcome: remoteOutcomeReconfigure, remote: cfg.remoteName}
+		}
+
+		// Need to create remote - trigger configuration
+		return remoteCheckMsg{outcome: remoteOutcomeNeedsConfig, remote: cfg.remoteName}
+	}
+}
+
+func configureRemote(cfg config) tea.Cmd {
+	return func() tea.Msg {
+		logFile := filepath.Join(cfg.logDir, "remote-config.log")
+
+		cmd := exec.Command("rclone", "config", "create",
+			cfg.remoteName, "sftp",
+			"host", cfg.remoteHost,
+			"user", cfg.remoteUser,
+			"key_file", cfg.sshKey,
+			"shell_type", "unix",
+			"md5sum_command", "md5sum",
+			"sha1sum_command", "sha1sum",
+		)
+
+		file, err := os.Create(logFile)
+		if err == nil {
+			cmd.Stdout = file
+			cmd.Stderr = file
+		}
+
+		if err := cmd.Run(); err != nil {
+			return errMsg{fmt.Errorf("failed to configure remote: %v", err)}
+		}
+		if file != nil {
+			_ = file.Close()
+		}
+
+		return remoteConfiguredMsg{remote: cfg.remoteName}
+	}
+}
+
+func testRemote(cfg config) tea.Cmd {
+	return func() tea.Msg {
+		cmd := exec.Command("rclone", "lsd", cfg.remoteName+":")
+		cmd.Stderr = nil
+		cmd.Stdout = nil
+		if err := cmd.Run(); err == nil {
+			return remoteTestMsg{ok: true, remote: cfg.remoteName}
+		}
+		return remoteTestMsg{ok: false, remote: cfg.remoteName}
+	}
+}
+
+// buildExclusionFile walks cfg.localDir for .git/node_modules directories
+// and writes their relative paths to cfg.exclusionFile, which every
+// rclone invocation (sync, bisync, dry-run) then feeds in via
+// --exclude-from/ExcludeFrom -- the single source of truth for what gets
+// excluded, so a custom --exclude-file path actually takes effect.
+func buildExclusionFile(cfg config) (gitCount, nodeModulesCount int, err error) {
+	file, err := os.Create(cfg.exclusionFile)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to create exclusion file: %w", err)
+	}
+
+	writer := bufio.NewWriter(file)
+	if _, err := writer.WriteString("# Rclone SSH Sync Exclusion List\n"); err != nil {
+		_ = file.Close()
+		return 0, 0, fmt.Errorf("failed to write exclusion file: %w", err)
+	}
+	if _, err := writer.WriteString("# Generated: " + time.Now().UTC().Format(time.RFC3339) + "\n"); err != nil {
+		_ = file.Close()
+		return 0, 0, fmt.Errorf("failed to write exclusion file: %w", err)
+	}
+	if _, err := writer.WriteString("# Repository: " + cfg.localDir + "\n\n"); err != nil {
+		_ = file.Close()
+		return 0, 0, fmt.Errorf("failed to write exclusion file: %w", err)
+	}
+
+	// Find .git directories
+	if walkErr := filepath.Walk(cfg.localDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() && info.Name() == ".git" {
+			relPath, _ := filepath.Rel(cfg.localDir, path)
+			if _, err := writer.WriteString(relPath + "\n"); err != nil {
+				return err
+			}
+			gitCount++
+		}
+		return nil
+	}); walkErr != nil {
+		_ = file.Close()
+		return 0, 0, fmt.Errorf("failed to scan for .git directories: %w", walkErr)
+	}
+
+	// Find node_modules directories
+	if walkErr := filepath.Walk(cfg.localDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() && info.Name() == "node_modules" {
+			relPath, _ := filepath.Rel(cfg.localDir, path)
+			if _, err := writer.WriteString(relPath + "\n"); err != nil {
+				return err
+			}
+			nodeModulesCount++
+		}
+		return nil
+	}); walkErr != nil {
+		_ = file.Close()
+		return 0, 0, fmt.Errorf("failed to scan for node_modules directories: %w", walkErr)
+	}
+
+	if err := writer.Flush(); err != nil {
+		_ = file.Close()
+		return 0, 0, fmt.Errorf("failed to flush exclusion file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return 0, 0, fmt.Errorf("failed to close exclusion file: %w", err)
+	}
+
+	return gitCount, nodeModulesCount, nil
+}
+
+func generateExclusions(cfg config) tea.Cmd {
+	return func() tea.Msg {
+		gitCount, nodeModulesCount, err := buildExclusionFile(cfg)
+		if err != nil {
+			return errMsg{err}
+		}
+		return exclusionCountMsg{git: gitCount, nodeModules: nodeModulesCount}
+	}
+}
+
+func generateExclusionsStatus(gitCount, nodeModulesCount int) tea.Msg {
+	return statusMsg{i18n.Tf("✓ Exclusion list generated: %d .git, %d node_modules", gitCount, nodeModulesCount)}
+}
+
+// startSync launches rclone in remote-control mode and kicks off an async
+// sync/copy job, handing control back to the poller instead of piping
+// rclone's own stdout (which corrupted the alt-screen).
+func startSync(cfg config, filesToTransfer []string) tea.Cmd {
+	return func() tea.Msg {
+		logFile := filepath.Join(cfg.logDir, "rcd.log")
+
+		rc, err := startRcDaemon(cfg, logFile)
+		if err != nil {
+			return errMsg{fmt.Errorf("failed to start rclone rc daemon: %w", err)}
+		}
+
+		if err := rc.startJob(cfg, filesToTransfer); err != nil {
+			rc.shutdown()
+			return errMsg{fmt.Errorf("failed to start sync job: %w", err)}
+		}
+
+		return rcStartedMsg{rc: rc}
+	}
+}
+
+// pollRc queries the rc daemon for the current job's stats and status on a
+// short tick, translating the JSON responses into tea.Msg values.
+func pollRc(rc *rcDaemon) tea.Cmd {
+	return func() tea.Msg {
+		time.Sleep(500 * time.Millisecond)
+
+		status, err := rc.jobStatus()
+		if err != nil {
+			return errMsg{fmt.Errorf("rc job/status call failed: %w", err)}
+		}
+		if status.Finished {
+			if status.Error != "" {
+				return jobDoneMsg{success: false, err: fmt.Errorf("rclone job failed: %s", status.Error)}
+			}
+			return jobDoneMsg{success: status.Success}
+		}
+
+		stats, err := rc.stats()
+		if err != nil {
+			return errMsg{fmt.Errorf("rc core/stats call failed: %w", err)}
+		}
+
+		return overallStatsMsgFrom(stats)
+	}
+}
+
+func overallStatsMsgFrom(stats rcStats) tea.Msg {
+	return tea.Batch(
+		func() tea.Msg {
+			return overallStatsMsg{
+				bytesDone:  stats.Bytes,
+				bytesTotal: stats.TotalBytes,
+				speed:      stats.Speed,
+				eta:        time.Duration(stats.ETA) * time.Second,
+			}
+		},
+		func() tea.Msg { return transferMsg{transfers: stats.Transferring} },
+		func() tea.Msg { return rcPollTickMsg{} },
+	)()
+}
+
+func spinnerTick() tea.Msg {
+	time.Sleep(100 * time.Millisecond)
+	return spinnerTickMsg{}
+}
+
+// runSyncTUI drives the Bubble Tea sync model to completion (or error) and
+// is the view layer shared by the "sync" and "watch" subcommands.
+func runSyncTUI(cfg config, rehash bool) error {
+	return runTUI(cfg, false, rehash)
+}
+
+// runBisyncTUI drives the same model in bisync mode, where the "generating"
+// state hands off to bisyncStart instead of startSync.
+func runBisyncTUI(cfg config) error {
+	return runTUI(cfg, true, false)
+}
+
+func runTUI(cfg config, bisyncMode, rehash bool) error {
+	if err := os.MkdirAll(cfg.logDir, 0755); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(cfg.logDir) }()
+
+	p := tea.NewProgram(initialModelMode(cfg, bisyncMode, rehash), tea.WithAltScreen())
+	finalModel, err := p.Run()
+	if err != nil {
+		return fmt.Errorf("sync TUI exited with an error: %w", err)
+	}
+
+	if m, ok := finalModel.(model); ok && m.err != nil {
+		return m.err
+	}
+	return nil
+}